@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// EnvAuthDir overrides where porter looks for auth.d credential
+// assignment files.
+const EnvAuthDir = "PORTER_AUTH_DIR"
+
+// authDirName is the default auth.d directory name, relative to the
+// porter home directory.
+const authDirName = "auth.d"
+
+// GetAuthDir returns the directory porter consults to auto-select a
+// credential set for a bundle reference, honoring PORTER_AUTH_DIR and
+// falling back to <home>/auth.d.
+func (c *Config) GetAuthDir() (string, error) {
+	if dir := os.Getenv(EnvAuthDir); dir != "" {
+		return dir, nil
+	}
+
+	home, err := c.GetHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, authDirName), nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	cxt "get.porter.sh/porter/pkg/context"
+)
+
+// EnvHOME is the environment variable that overrides where porter looks
+// for its home directory.
+const EnvHOME = "PORTER_HOME"
+
+// Config holds the dependencies shared across porter, on top of the raw
+// Context of filesystem/streams.
+type Config struct {
+	*cxt.Context
+}
+
+// New creates a Config wired up to the real OS filesystem.
+func New() *Config {
+	return &Config{Context: cxt.New()}
+}
+
+// GetHomeDir returns the path to the porter home directory, honoring
+// PORTER_HOME when set and falling back to ~/.porter.
+func (c *Config) GetHomeDir() (string, error) {
+	if home := os.Getenv(EnvHOME); home != "" {
+		return home, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".porter"), nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"path/filepath"
+
+	"get.porter.sh/porter/pkg/secrets"
+	"github.com/spf13/afero"
+)
+
+// configFileName is the porter home configuration file that, among other
+// things, declares the external secret store backends available for
+// credential resolution.
+const configFileName = "config.toml"
+
+// GetSecretsConfig loads the `[secrets]` section of config.toml from the
+// porter home directory. A missing config.toml is not an error: it just
+// means no external secret store backends are configured.
+func (c *Config) GetSecretsConfig() (*secrets.Config, error) {
+	home, err := c.GetHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	configPath := filepath.Join(home, configFileName)
+	exists, err := afero.Exists(c.FileSystem, configPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &secrets.Config{}, nil
+	}
+
+	f, err := c.FileSystem.Open(configPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return secrets.ReadConfig(f)
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	cxt "get.porter.sh/porter/pkg/context"
+)
+
+// TestConfig wraps Config with a Context pre-wired to a real, throwaway
+// porter home directory, so tests exercising file permissions (like
+// credential persistence) see real, enforced permission bits rather than
+// an in-memory filesystem's best-effort approximation of them.
+type TestConfig struct {
+	*Config
+
+	TestContext *cxt.TestContext
+	t           *testing.T
+}
+
+// NewTestConfig creates a Config for testing, rooted at a fresh temporary
+// directory that's removed when the test completes.
+func NewTestConfig(t *testing.T) *TestConfig {
+	tc := cxt.NewTestContext()
+	home := t.TempDir()
+	tc.UseFilesystem(home)
+	t.Setenv(EnvHOME, home)
+
+	return &TestConfig{
+		Config:      &Config{Context: tc.Context},
+		TestContext: tc,
+		t:           t,
+	}
+}
+
+// SetupPorterHome creates the porter home directory so tests don't need
+// to handle ENOENT themselves.
+func (c *TestConfig) SetupPorterHome() {
+	home, err := c.GetHomeDir()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	if err := c.FileSystem.MkdirAll(home, 0700); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// UseFilesystem returns the real, temporary porter home directory backing
+// this TestConfig. Kept around for tests that construct their own
+// storage directly against the filesystem; NewTestConfig already backs
+// every TestConfig with a real filesystem, so this no longer needs to
+// swap anything in.
+func (c *TestConfig) UseFilesystem() string {
+	home, err := c.GetHomeDir()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return home
+}
@@ -0,0 +1,30 @@
+package context
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// Context provides a shared bag of dependencies that every operation needs
+// access to: the filesystem, and the standard streams. It exists so that
+// commands can be run against a real OS filesystem in production and an
+// in-memory one in tests.
+type Context struct {
+	FileSystem afero.Fs
+	In         io.Reader
+	Out        io.Writer
+	Err        io.Writer
+}
+
+// New creates a Context wired up to the real OS filesystem and standard
+// streams.
+func New() *Context {
+	return &Context{
+		FileSystem: afero.NewOsFs(),
+		In:         os.Stdin,
+		Out:        os.Stdout,
+		Err:        os.Stderr,
+	}
+}
@@ -0,0 +1,45 @@
+package context
+
+import (
+	"bytes"
+
+	"github.com/spf13/afero"
+)
+
+// TestContext wraps Context with an in-memory filesystem and captured
+// output streams so that tests can assert on what was printed without
+// touching the real filesystem.
+type TestContext struct {
+	*Context
+
+	outBuffer *bytes.Buffer
+}
+
+// NewTestContext creates a Context backed by an in-memory filesystem with
+// its output captured for assertions.
+func NewTestContext() *TestContext {
+	outBuffer := &bytes.Buffer{}
+	return &TestContext{
+		Context: &Context{
+			FileSystem: afero.NewMemMapFs(),
+			In:         &bytes.Buffer{},
+			Out:        outBuffer,
+			Err:        &bytes.Buffer{},
+		},
+		outBuffer: outBuffer,
+	}
+}
+
+// GetOutput returns everything written to Out so far.
+func (c *TestContext) GetOutput() string {
+	return c.outBuffer.String()
+}
+
+// UseFilesystem swaps the in-memory filesystem for the real OS
+// filesystem, for tests that need to exercise real file permissions and
+// atomic rename semantics. Callers are expected to confine themselves to
+// a throwaway directory (e.g. one from t.TempDir()) since paths are
+// resolved against the real filesystem unscoped.
+func (c *TestContext) UseFilesystem(home string) {
+	c.FileSystem = afero.NewOsFs()
+}
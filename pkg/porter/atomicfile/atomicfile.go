@@ -0,0 +1,70 @@
+// Package atomicfile provides a way to write sensitive files (like
+// credential sets) to disk without ever leaving a partial or
+// world-readable file behind, even if the process crashes mid-write.
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomicWithPerms writes data to path without ever exposing a
+// partially-written or wrongly-permissioned file at that path: it writes
+// to a temp file in the same directory as path, chmods it to filePerm,
+// fsyncs it, and only then renames it into place. The directory is
+// created (if missing) with dirPerm. If anything fails along the way,
+// path is left untouched and the temp file is cleaned up.
+func WriteAtomicWithPerms(path string, data []byte, dirPerm, filePerm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+	// MkdirAll is a no-op when dir already exists, so a pre-existing
+	// directory created with looser permissions would otherwise keep
+	// them. Force dirPerm either way.
+	if err := os.Chmod(dir, dirPerm); err != nil {
+		return fmt.Errorf("chmod %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+
+	renamed := false
+	defer func() {
+		if !renamed {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing %s: %w", tmpPath, err)
+	}
+
+	if err := tmp.Chmod(filePerm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod %s: %w", tmpPath, err)
+	}
+
+	// Fsync before rename so that, on POSIX filesystems, a crash can't
+	// leave the renamed file pointing at unwritten data.
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("fsync %s: %w", tmpPath, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("renaming %s to %s: %w", tmpPath, path, err)
+	}
+	renamed = true
+
+	return nil
+}
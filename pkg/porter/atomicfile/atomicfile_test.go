@@ -0,0 +1,65 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAtomicWithPerms_CreatesFileAndDirWithPerms(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "creds")
+	path := filepath.Join(dir, "kool-kreds.json")
+
+	err := WriteAtomicWithPerms(path, []byte(`{"name":"kool-kreds"}`), 0700, 0600)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"kool-kreds"}`, string(data))
+
+	if runtime.GOOS != "windows" {
+		dirInfo, err := os.Stat(dir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0700), dirInfo.Mode().Perm())
+
+		fileInfo, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temp file should be left behind")
+}
+
+func TestWriteAtomicWithPerms_FailureLeavesOldFileIntact(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits don't prevent writes on windows")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kool-kreds.json")
+
+	require.NoError(t, WriteAtomicWithPerms(path, []byte("original"), 0700, 0600))
+
+	// Make the directory read-only so the temp file can't be created,
+	// simulating a write that fails partway through. WriteAtomicWithPerms
+	// chmods an existing directory to dirPerm, so dirPerm is read-only too.
+	require.NoError(t, os.Chmod(dir, 0500))
+	t.Cleanup(func() { os.Chmod(dir, 0700) })
+
+	err := WriteAtomicWithPerms(path, []byte("new content that never lands"), 0500, 0600)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(data), "the original file should survive a failed write")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no *.tmp file should be left behind after a failed write")
+}
@@ -0,0 +1,139 @@
+package porter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// AuthEntry is a single `bundle <glob> credentials <name>` line parsed
+// out of an auth.d file, along with where it came from, for diagnostics.
+type AuthEntry struct {
+	File           string
+	Line           int
+	BundleGlob     string
+	CredentialName string
+}
+
+// parseAuthFile parses the auth.d line format:
+//
+//	bundle ghcr.io/myorg/*  credentials kool-kreds
+//
+// Blank lines and lines starting with # are ignored. Any other line that
+// doesn't match the expected shape is a parse error naming the file and
+// line number responsible.
+func parseAuthFile(path string, r io.Reader) ([]AuthEntry, error) {
+	var entries []AuthEntry
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 4 || fields[0] != "bundle" || fields[2] != "credentials" {
+			return nil, fmt.Errorf(`%s:%d: malformed line, expected "bundle <glob> credentials <name>"`, path, lineNum)
+		}
+
+		entries = append(entries, AuthEntry{
+			File:           path,
+			Line:           lineNum,
+			BundleGlob:     fields[1],
+			CredentialName: fields[3],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// ResolveAuth finds which auth.d entry under dir matches bundleRef, if
+// any. When more than one entry matches, the entry with the longest glob
+// wins (the more specific match); ties are broken by file order (sorted
+// by filename) and then by line order within a file.
+//
+// A missing auth.d directory is not an error: found is simply false.
+func ResolveAuth(fs afero.Fs, dir, bundleRef string) (entry AuthEntry, found bool, err error) {
+	exists, err := afero.DirExists(fs, dir)
+	if err != nil {
+		return AuthEntry{}, false, err
+	}
+	if !exists {
+		return AuthEntry{}, false, nil
+	}
+
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return AuthEntry{}, false, err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name() < infos[j].Name() })
+
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, info.Name())
+		f, err := fs.Open(path)
+		if err != nil {
+			return AuthEntry{}, false, err
+		}
+		entries, err := parseAuthFile(path, f)
+		f.Close()
+		if err != nil {
+			return AuthEntry{}, false, err
+		}
+
+		for _, e := range entries {
+			if !matchBundleGlob(e.BundleGlob, bundleRef) {
+				continue
+			}
+
+			if !found || len(e.BundleGlob) > len(entry.BundleGlob) {
+				entry = e
+				found = true
+			}
+		}
+	}
+
+	return entry, found, nil
+}
+
+// matchBundleGlob reports whether bundleRef matches glob, an
+// apt-auth.conf.d-style pattern where "*" matches any sequence of
+// characters, including "/" — unlike filepath.Match, so that
+// "ghcr.io/myorg/*" matches every bundle under myorg, no matter how many
+// path segments deep.
+func matchBundleGlob(glob, bundleRef string) bool {
+	star, gi, match, bi := -1, 0, 0, 0
+	for bi < len(bundleRef) {
+		switch {
+		case gi < len(glob) && glob[gi] == bundleRef[bi]:
+			gi++
+			bi++
+		case gi < len(glob) && glob[gi] == '*':
+			star, match = gi, bi
+			gi++
+		case star != -1:
+			gi, match = star+1, match+1
+			bi = match
+		default:
+			return false
+		}
+	}
+	for gi < len(glob) && glob[gi] == '*' {
+		gi++
+	}
+	return gi == len(glob)
+}
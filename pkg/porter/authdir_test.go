@@ -0,0 +1,103 @@
+package porter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAuth_MissingDirectory(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	_, found, err := ResolveAuth(fs, "/home/.porter/auth.d", "ghcr.io/myorg/mybundle")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestResolveAuth_MalformedLineReportsLineNumber(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "/home/.porter/auth.d"
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "creds.conf"), []byte(
+		"bundle ghcr.io/myorg/* credentials kool-kreds\nthis line is nonsense\n",
+	), 0600))
+
+	_, _, err := ResolveAuth(fs, dir, "ghcr.io/myorg/mybundle")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "creds.conf:2")
+}
+
+func TestResolveAuth_LongestGlobWins(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "/home/.porter/auth.d"
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "creds.conf"), []byte(
+		"bundle ghcr.io/myorg/* credentials generic-kreds\n"+
+			"bundle ghcr.io/myorg/mybundle credentials specific-kreds\n",
+	), 0600))
+
+	entry, found, err := ResolveAuth(fs, dir, "ghcr.io/myorg/mybundle")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "specific-kreds", entry.CredentialName)
+	assert.Equal(t, 2, entry.Line)
+}
+
+func TestResolveAuth_FirstFileWinsOnTies(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "/home/.porter/auth.d"
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "a-first.conf"), []byte(
+		"bundle ghcr.io/myorg/mybundle credentials from-a\n",
+	), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "b-second.conf"), []byte(
+		"bundle ghcr.io/myorg/mybundle credentials from-b\n",
+	), 0600))
+
+	entry, found, err := ResolveAuth(fs, dir, "ghcr.io/myorg/mybundle")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "from-a", entry.CredentialName)
+}
+
+func TestResolveAuth_GlobSpansMultipleSegments(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "/home/.porter/auth.d"
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "creds.conf"), []byte(
+		"bundle ghcr.io/myorg/* credentials kool-kreds\n",
+	), 0600))
+
+	entry, found, err := ResolveAuth(fs, dir, "ghcr.io/myorg/team/mybundle")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "kool-kreds", entry.CredentialName)
+}
+
+func TestResolveAuth_NoMatch(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir := "/home/.porter/auth.d"
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "creds.conf"), []byte(
+		"bundle ghcr.io/otherorg/* credentials other-kreds\n",
+	), 0600))
+
+	_, found, err := ResolveAuth(fs, dir, "ghcr.io/myorg/mybundle")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestResolveCredentialAssignment_NoMatchIsClean(t *testing.T) {
+	p := NewTestPorter(t)
+	p.TestConfig.SetupPorterHome()
+	p.CNAB = &TestCNABProvider{}
+
+	err := p.ResolveCredentialAssignment(CredentialResolveOptions{BundleReference: "ghcr.io/myorg/mybundle"})
+	require.NoError(t, err)
+	assert.Equal(t, "no match", trimTrailingNewline(p.TestConfig.TestContext.GetOutput()))
+}
+
+func trimTrailingNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
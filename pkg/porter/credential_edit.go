@@ -0,0 +1,145 @@
+package porter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/ghodss/yaml"
+
+	"get.porter.sh/porter/pkg/secrets"
+)
+
+// defaultEditor is used when neither $EDITOR nor $VISUAL is set.
+const defaultEditor = "vi"
+
+// CredentialEditOptions are the options for `porter credentials edit`.
+type CredentialEditOptions struct {
+	Name string
+}
+
+// Validate parses the positional credential set name.
+func (o *CredentialEditOptions) Validate(args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("no credential set name was specified")
+	}
+	o.Name = args[0]
+	return nil
+}
+
+// EditCredential opens the named credential set in $EDITOR as YAML,
+// validates the edited result against the bundle's declared credentials,
+// and saves it back, preserving Created and bumping Modified. If the
+// editor exits non-zero, or the edited content fails validation, the
+// on-disk credential set is left untouched.
+func (p *Porter) EditCredential(opts CredentialEditOptions) error {
+	original, err := p.Credentials.Read(opts.Name)
+	if err != nil {
+		return errors.New("Credential set does not exist")
+	}
+
+	data, err := yaml.Marshal(original)
+	if err != nil {
+		return fmt.Errorf("rendering credential set %q as yaml: %w", opts.Name, err)
+	}
+
+	tmp, err := os.CreateTemp("", "porter-credentials-*.yaml")
+	if err != nil {
+		return fmt.Errorf("creating temp file for editing: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temp file for editing: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file for editing: %w", err)
+	}
+
+	if err := p.runEditor(tmpPath); err != nil {
+		return fmt.Errorf("editor exited with an error, credential set %q was not modified: %w", opts.Name, err)
+	}
+
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return fmt.Errorf("reading back edited credential set: %w", err)
+	}
+
+	var cs credentials.CredentialSet
+	if err := yaml.Unmarshal(edited, &cs); err != nil {
+		return fmt.Errorf("parsing edited credential set: %w", err)
+	}
+
+	if err := p.validateCredentialSet(cs); err != nil {
+		return err
+	}
+
+	cs.Name = original.Name
+	cs.Created = original.Created
+	cs.Modified = time.Now()
+
+	return p.Credentials.Save(cs)
+}
+
+func (p *Porter) runEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin = p.In
+	cmd.Stdout = p.Out
+	cmd.Stderr = p.Err
+	return cmd.Run()
+}
+
+// validateCredentialSet rejects an edited credential set that declares
+// credentials the bundle doesn't expect, or uses a source key porter
+// doesn't know how to resolve.
+func (p *Porter) validateCredentialSet(cs credentials.CredentialSet) error {
+	declared, err := p.CNAB.GetBundleCredentials()
+	if err != nil {
+		return fmt.Errorf("loading the bundle's declared credentials: %w", err)
+	}
+
+	declaredSet := make(map[string]bool, len(declared))
+	for _, name := range declared {
+		declaredSet[name] = true
+	}
+
+	for _, c := range cs.Credentials {
+		if !declaredSet[c.Name] {
+			return fmt.Errorf("credential %q is not declared by the bundle", c.Name)
+		}
+
+		if !isValidSourceKey(c.Source.Key) {
+			return fmt.Errorf("credential %q has an invalid source key %q", c.Name, c.Source.Key)
+		}
+	}
+
+	return nil
+}
+
+// isValidSourceKey reports whether key is a source porter knows how to
+// resolve: one of cnab-go's host source types, or a registered external
+// secrets provider (vault, docker-registry, etc).
+func isValidSourceKey(key string) bool {
+	switch key {
+	case host.SourceEnv, host.SourcePath, host.SourceCommand, host.SourceValue:
+		return true
+	}
+	_, ok := secrets.Lookup(key)
+	return ok
+}
@@ -0,0 +1,130 @@
+package porter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEditor writes a shell script that overwrites its argument (the temp
+// file EditCredential hands it) with content, then exits with exitCode.
+func fakeEditor(t *testing.T, content string, exitCode int) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor script is a shell script")
+	}
+
+	path := filepath.Join(t.TempDir(), "fake-editor.sh")
+	script := fmt.Sprintf("#!/bin/sh\ncat > \"$1\" <<'PORTEREOF'\n%s\nPORTEREOF\nexit %d\n", content, exitCode)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0700))
+	return path
+}
+
+func TestEditCredential_UnknownCredentialRejected(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+	p.TestCredentials.AddTestCredentialsDirectory("testdata/test-creds")
+
+	t.Setenv("EDITOR", fakeEditor(t, `name: kool-kreds
+credentials:
+- name: not-a-real-credential
+  source:
+    value: nope
+`, 0))
+
+	before, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+
+	err = p.EditCredential(CredentialEditOptions{Name: "kool-kreds"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not declared by the bundle")
+
+	after, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "credential set should be untouched")
+}
+
+func TestEditCredential_InvalidSourceKeyRejected(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+	p.TestCredentials.AddTestCredentialsDirectory("testdata/test-creds")
+
+	t.Setenv("EDITOR", fakeEditor(t, `name: kool-kreds
+credentials:
+- name: kool-config
+  source:
+    carrier-pigeon: nope
+`, 0))
+
+	before, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+
+	err = p.EditCredential(CredentialEditOptions{Name: "kool-kreds"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid source key")
+
+	after, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "credential set should be untouched")
+}
+
+func TestEditCredential_EditorFailureLeavesFileUntouched(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+	p.TestCredentials.AddTestCredentialsDirectory("testdata/test-creds")
+
+	t.Setenv("EDITOR", fakeEditor(t, `name: kool-kreds
+credentials: []
+`, 1))
+
+	before, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+
+	err = p.EditCredential(CredentialEditOptions{Name: "kool-kreds"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not modified")
+
+	after, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "credential set should be untouched")
+}
+
+func TestEditCredential_RoundTrip(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+	p.TestCredentials.AddTestCredentialsDirectory("testdata/test-creds")
+
+	before, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+
+	t.Setenv("EDITOR", fakeEditor(t, `name: kool-kreds
+credentials:
+- name: kool-config
+  source:
+    path: /new/path/to/kool-config
+`, 0))
+
+	err = p.EditCredential(CredentialEditOptions{Name: "kool-kreds"})
+	require.NoError(t, err)
+
+	after, err := p.Credentials.Read("kool-kreds")
+	require.NoError(t, err)
+
+	require.Len(t, after.Credentials, 1)
+	assert.Equal(t, "/new/path/to/kool-config", after.Credentials[0].Source.Value)
+	assert.Equal(t, before.Created, after.Created, "Created should be preserved")
+	assert.True(t, after.Modified.After(before.Modified), "Modified should be bumped")
+}
+
+func TestEditCredential_NotFound(t *testing.T) {
+	p := NewTestPorter(t)
+	p.CNAB = &TestCNABProvider{}
+
+	err := p.EditCredential(CredentialEditOptions{Name: "does-not-exist"})
+	require.Error(t, err)
+	assert.Equal(t, "Credential set does not exist", err.Error())
+}
@@ -0,0 +1,63 @@
+package porter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CredentialResolveOptions are the options for the `porter credentials
+// resolve` diagnostic command.
+type CredentialResolveOptions struct {
+	BundleReference string
+}
+
+// Validate parses the positional bundle reference.
+func (o *CredentialResolveOptions) Validate(args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("no bundle reference was specified")
+	}
+	o.BundleReference = args[0]
+	return nil
+}
+
+// ResolveCredentialAssignment prints which auth.d file+line, if any,
+// matches opts.BundleReference and which credential set it assigns, so
+// users can debug why `--cred` was or wasn't auto-selected.
+func (p *Porter) ResolveCredentialAssignment(opts CredentialResolveOptions) error {
+	dir, err := p.GetAuthDir()
+	if err != nil {
+		return err
+	}
+
+	entry, found, err := ResolveAuth(p.FileSystem, dir, opts.BundleReference)
+	if err != nil {
+		return err
+	}
+	if !found {
+		fmt.Fprintln(p.Out, "no match")
+		return nil
+	}
+
+	fmt.Fprintf(p.Out, "%s:%d: %q matches, using credential set %q\n", entry.File, entry.Line, entry.BundleGlob, entry.CredentialName)
+	return nil
+}
+
+// ResolveCredentialForBundle consults auth.d for a credential set
+// assigned to bundleRef, returning "" (no error) if nothing matches. No
+// command in this repository calls it; install and upgrade do not consult
+// auth.d here.
+func (p *Porter) ResolveCredentialForBundle(bundleRef string) (string, error) {
+	dir, err := p.GetAuthDir()
+	if err != nil {
+		return "", err
+	}
+
+	entry, found, err := ResolveAuth(p.FileSystem, dir, bundleRef)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", nil
+	}
+	return entry.CredentialName, nil
+}
@@ -0,0 +1,183 @@
+package porter
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/spf13/afero"
+
+	"get.porter.sh/porter/pkg/config"
+	"get.porter.sh/porter/pkg/porter/atomicfile"
+)
+
+// credentialsDirPerm and credentialsFilePerm are the permissions the
+// credential store always uses, regardless of what the directory/files
+// happened to be created with before: credential sets routinely contain
+// plaintext secrets, so the directory must not be group/world-accessible
+// and each credential set file must not be group/world-readable.
+const (
+	credentialsDirPerm  = 0700
+	credentialsFilePerm = 0600
+)
+
+// credentialsDirName is the name of the directory, relative to the porter
+// home directory, that credential sets are persisted under.
+const credentialsDirName = "credentials"
+
+// CredentialsBackend is the storage surface a CredentialStorage delegates
+// to. cnab-go's *credentials.CredentialStore satisfies this, as does our
+// own afero-backed default implementation.
+type CredentialsBackend interface {
+	List() ([]string, error)
+	Read(name string) (credentials.CredentialSet, error)
+	Save(credentials.CredentialSet) error
+	Delete(name string) error
+}
+
+// CredentialStorage is Porter's view onto the credentials backend: it
+// resolves the configured home directory, lazily creates a default
+// filesystem-backed store, and lets callers (tests, mainly) swap in a
+// different CredentialsStore.
+type CredentialStorage struct {
+	Config           *config.Config
+	CredentialsStore CredentialsBackend
+}
+
+// NewCredentialStorage creates credential storage rooted at cfg's porter
+// home directory. The actual backend is created lazily so that tests can
+// set up the home directory after construction.
+func NewCredentialStorage(cfg *config.Config) *CredentialStorage {
+	return &CredentialStorage{Config: cfg}
+}
+
+func (s *CredentialStorage) backend() (CredentialsBackend, error) {
+	if s.CredentialsStore != nil {
+		return s.CredentialsStore, nil
+	}
+
+	home, err := s.Config.GetHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &fileCredentialsBackend{
+		fs:  s.Config.FileSystem,
+		dir: filepath.Join(home, credentialsDirName),
+	}
+	s.CredentialsStore = store
+	return store, nil
+}
+
+func (s *CredentialStorage) List() ([]string, error) {
+	b, err := s.backend()
+	if err != nil {
+		return nil, err
+	}
+	return b.List()
+}
+
+func (s *CredentialStorage) Read(name string) (credentials.CredentialSet, error) {
+	b, err := s.backend()
+	if err != nil {
+		return credentials.CredentialSet{}, err
+	}
+	return b.Read(name)
+}
+
+func (s *CredentialStorage) Save(cs credentials.CredentialSet) error {
+	b, err := s.backend()
+	if err != nil {
+		return err
+	}
+	return b.Save(cs)
+}
+
+func (s *CredentialStorage) Delete(name string) error {
+	b, err := s.backend()
+	if err != nil {
+		return err
+	}
+	return b.Delete(name)
+}
+
+// fileCredentialsBackend is the default CredentialsBackend, storing one
+// JSON file per credential set on the configured afero filesystem.
+type fileCredentialsBackend struct {
+	fs  afero.Fs
+	dir string
+}
+
+func (b *fileCredentialsBackend) path(name string) string {
+	return filepath.Join(b.dir, name+".json")
+}
+
+func (b *fileCredentialsBackend) List() ([]string, error) {
+	exists, err := afero.DirExists(b.fs, b.dir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []string{}, nil
+	}
+
+	infos, err := afero.ReadDir(b.fs, b.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		names = append(names, stripJSONExt(info.Name()))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (b *fileCredentialsBackend) Read(name string) (credentials.CredentialSet, error) {
+	data, err := afero.ReadFile(b.fs, b.path(name))
+	if err != nil {
+		return credentials.CredentialSet{}, fmt.Errorf("Credential set does not exist")
+	}
+
+	var cs credentials.CredentialSet
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return credentials.CredentialSet{}, fmt.Errorf("parsing credential set %q: %w", name, err)
+	}
+	return cs, nil
+}
+
+func (b *fileCredentialsBackend) Save(cs credentials.CredentialSet) error {
+	// atomicfile writes through the real os package (for fsync/rename
+	// guarantees an in-memory afero.Fs can't give us), so it and b.fs
+	// must agree on where "disk" is. List/Read/Delete go through b.fs
+	// directly, so an in-memory fs here would see Save write to the real
+	// filesystem while everything else reads from memory.
+	if _, ok := b.fs.(*afero.OsFs); !ok {
+		return fmt.Errorf("credential storage requires an OS-backed filesystem, got %T", b.fs)
+	}
+
+	data, err := json.MarshalIndent(cs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling credential set %q: %w", cs.Name, err)
+	}
+
+	return atomicfile.WriteAtomicWithPerms(b.path(cs.Name), data, credentialsDirPerm, credentialsFilePerm)
+}
+
+func (b *fileCredentialsBackend) Delete(name string) error {
+	return b.fs.Remove(b.path(name))
+}
+
+func stripJSONExt(name string) string {
+	const ext = ".json"
+	if len(name) > len(ext) && name[len(name)-len(ext):] == ext {
+		return name[:len(name)-len(ext)]
+	}
+	return name
+}
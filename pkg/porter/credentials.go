@@ -0,0 +1,427 @@
+package porter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cnabio/cnab-go/credentials"
+	"github.com/cnabio/cnab-go/secrets/host"
+	"github.com/ghodss/yaml"
+
+	"get.porter.sh/porter/pkg/printer"
+	"get.porter.sh/porter/pkg/secrets"
+)
+
+// credentialNamePattern restricts credential set names to the characters
+// porter's own file-backed storage can safely use as a filename.
+var credentialNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+
+func validateCredentialName(name string) error {
+	if !credentialNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid credential set name %q: names may only contain letters, numbers and dashes", name)
+	}
+	return nil
+}
+
+// CredentialOptions are the options for generating a new credential set
+// with `porter credentials generate`.
+type CredentialOptions struct {
+	// Name of the credential set to generate. Defaults to the name of the
+	// bundle in scope.
+	Name string
+
+	// Silent skips the interactive prompts and generates an empty
+	// credential set for the caller to fill in by hand.
+	Silent bool
+}
+
+// GenerateCredentials creates a new credential set named after the bundle
+// in scope (or opts.Name, if given) and persists it. Unless opts.Silent is
+// set, it prompts for a source for each credential the bundle declares;
+// with opts.Silent the credential set is saved empty.
+func (p *Porter) GenerateCredentials(opts CredentialOptions) error {
+	name := opts.Name
+	if name == "" {
+		var err error
+		name, err = p.CNAB.LoadBundle()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := validateCredentialName(name); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cs := credentials.CredentialSet{
+		Name:     name,
+		Created:  now,
+		Modified: now,
+	}
+
+	if !opts.Silent {
+		declared, err := p.CNAB.GetBundleCredentials()
+		if err != nil {
+			return fmt.Errorf("loading the bundle's declared credentials: %w", err)
+		}
+
+		scanner := bufio.NewScanner(p.In)
+		for _, credName := range declared {
+			source, err := p.promptCredentialSource(scanner, credName)
+			if err != nil {
+				return err
+			}
+			cs.Credentials = append(cs.Credentials, credentials.CredentialStrategy{
+				Name:   credName,
+				Source: source,
+			})
+		}
+	}
+
+	return p.Credentials.Save(cs)
+}
+
+// credentialSourceChoice is one entry in the interactive "source type"
+// picker prompted for each credential by GenerateCredentials.
+type credentialSourceChoice struct {
+	label string
+	key   string
+}
+
+// credentialSourceChoices lists the source types GenerateCredentials
+// offers, in the order they're numbered for the user.
+func credentialSourceChoices() []credentialSourceChoice {
+	choices := []credentialSourceChoice{
+		{"environment variable", host.SourceEnv},
+		{"file path", host.SourcePath},
+		{"shell command", host.SourceCommand},
+		{"plain value", host.SourceValue},
+		{"docker credential helper", secrets.SourceDockerRegistry},
+	}
+	for _, key := range []string{secrets.SourceVault, secrets.SourceAWSSecretsManager, secrets.SourceAzureKeyVault, secrets.SourceGCPSecretManager} {
+		choices = append(choices, credentialSourceChoice{secrets.Label(key), key})
+	}
+	return choices
+}
+
+// promptCredentialSource interactively asks how to resolve credName,
+// picking among credentialSourceChoices, then collects whatever that
+// source type needs. Picking "docker credential helper" additionally
+// looks up the helper docker is already configured to use for the
+// registry entered, via DiscoverDockerRegistryHelper.
+func (p *Porter) promptCredentialSource(scanner *bufio.Scanner, credName string) (credentials.Source, error) {
+	choices := credentialSourceChoices()
+
+	fmt.Fprintf(p.Out, "credential %q\n", credName)
+	for i, choice := range choices {
+		fmt.Fprintf(p.Out, "  %d) %s\n", i+1, choice.label)
+	}
+
+	choice, err := p.promptChoice(scanner, "source type", choices)
+	if err != nil {
+		return credentials.Source{}, err
+	}
+
+	if choice.key == secrets.SourceDockerRegistry {
+		return p.promptDockerRegistrySource(scanner)
+	}
+
+	value, err := p.promptLine(scanner, choice.label)
+	if err != nil {
+		return credentials.Source{}, err
+	}
+	return credentials.Source{Key: choice.key, Value: value}, nil
+}
+
+// promptDockerRegistrySource asks for the registry a credential
+// authenticates against, then reports (but does not require overriding)
+// the docker-credential-* helper already configured for it, so the user
+// can confirm porter will resolve it the same way the docker CLI does.
+func (p *Porter) promptDockerRegistrySource(scanner *bufio.Scanner) (credentials.Source, error) {
+	registry, err := p.promptLine(scanner, "registry (e.g. ghcr.io)")
+	if err != nil {
+		return credentials.Source{}, err
+	}
+
+	if helper, ok := p.DiscoverDockerRegistryHelper(registry); ok {
+		fmt.Fprintf(p.Out, "using docker credential helper %q configured for %s\n", helper, registry)
+	} else {
+		fmt.Fprintf(p.Out, "no docker credential helper is configured for %s in ~/.docker/config.json; porter will fail to resolve this credential until one is added, or extra.helper is set for the docker-registry backend in config.toml\n", registry)
+	}
+
+	return credentials.Source{Key: secrets.SourceDockerRegistry, Value: registry}, nil
+}
+
+// promptChoice prompts for and reads a 1-based selection from choices.
+func (p *Porter) promptChoice(scanner *bufio.Scanner, prompt string, choices []credentialSourceChoice) (credentialSourceChoice, error) {
+	for {
+		line, err := p.promptLine(scanner, prompt)
+		if err != nil {
+			return credentialSourceChoice{}, err
+		}
+
+		i, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || i < 1 || i > len(choices) {
+			fmt.Fprintf(p.Out, "please enter a number between 1 and %d\n", len(choices))
+			continue
+		}
+		return choices[i-1], nil
+	}
+}
+
+// promptLine writes prompt to p.Out and reads back a single line from
+// scanner.
+func (p *Porter) promptLine(scanner *bufio.Scanner, prompt string) (string, error) {
+	fmt.Fprintf(p.Out, "%s: ", prompt)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("reading %s: %w", prompt, err)
+		}
+		return "", fmt.Errorf("reading %s: unexpected end of input", prompt)
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// DiscoverDockerRegistryHelper looks up which docker-credential-*
+// helper the user's docker CLI is configured to use for registry, so
+// that the credential generation prompt can report it when the user
+// picks "docker credential helper" as a credential's source. The helper
+// name is not stored in the credential set.
+func (p *Porter) DiscoverDockerRegistryHelper(registry string) (helper string, ok bool) {
+	helper, ok, err := secrets.DiscoverDockerCredentialHelper(secrets.DefaultDockerConfigPath(), registry)
+	if err != nil {
+		return "", false
+	}
+	return helper, ok
+}
+
+// ListCredentials prints out the names of the credential sets known to
+// porter, in opts.Format.
+func (p *Porter) ListCredentials(opts ListOptions) error {
+	if opts.RawFormat != "" {
+		if err := opts.ParseFormat(); err != nil {
+			return err
+		}
+	}
+	if opts.Format == "" {
+		opts.Format = printer.FormatTable
+	}
+	if err := validateFormat(opts.Format); err != nil {
+		return err
+	}
+
+	names, err := p.Credentials.List()
+	if err != nil {
+		return err
+	}
+
+	sets := make([]credentials.CredentialSet, 0, len(names))
+	for _, name := range names {
+		cs, err := p.Credentials.Read(name)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, cs)
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printJson(p.Out, sets)
+	case printer.FormatYaml:
+		return printYaml(p.Out, sets)
+	default:
+		w := tabwriter.NewWriter(p.Out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "NAME\tMODIFIED")
+		for _, cs := range sets {
+			fmt.Fprintf(w, "%s\t%s\n", cs.Name, cs.Modified.Format("2006-01-02"))
+		}
+		return w.Flush()
+	}
+}
+
+// CredentialShowOptions are the options for `porter credentials show`.
+type CredentialShowOptions struct {
+	printer.PrintOptions
+
+	Name string
+}
+
+// Validate parses the positional credential set name, preserving its
+// case, and the requested output format.
+func (o *CredentialShowOptions) Validate(args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("no credential set name was specified")
+	}
+	o.Name = args[0]
+
+	if o.RawFormat != "" {
+		return o.ParseFormat()
+	}
+	return nil
+}
+
+// ShowCredential prints the details of a single credential set in
+// opts.Format.
+func (p *Porter) ShowCredential(opts CredentialShowOptions) error {
+	cs, err := p.Credentials.Read(opts.Name)
+	if err != nil {
+		return errors.New("Credential set does not exist")
+	}
+
+	switch opts.Format {
+	case printer.FormatJson:
+		return printJson(p.Out, cs)
+	case printer.FormatYaml:
+		return printYaml(p.Out, cs)
+	default:
+		return printCredentialTable(p.Out, cs)
+	}
+}
+
+func printCredentialTable(w fmtWriter, cs credentials.CredentialSet) error {
+	fmt.Fprintf(w, "Name: %s\n", cs.Name)
+	fmt.Fprintf(w, "Created: %s\n", cs.Created.Format("2006-01-02"))
+	fmt.Fprintf(w, "Modified: %s\n\n", cs.Modified.Format("2006-01-02"))
+
+	headers := []string{"Name", "Local Source", "Source Type"}
+	rows := make([][]string, 0, len(cs.Credentials))
+	for _, c := range cs.Credentials {
+		value, sourceType := GetCredentialSourceValueAndType(c.Source)
+		rows = append(rows, []string{c.Name, value, sourceType})
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	total := 2
+	for _, width := range widths {
+		total += width + 2
+	}
+	sep := strings.Repeat("-", total)
+
+	fmt.Fprintln(w, sep)
+	fmt.Fprintln(w, formatCredentialRow(headers, widths))
+	fmt.Fprintln(w, sep)
+	for _, row := range rows {
+		fmt.Fprintln(w, formatCredentialRow(row, widths))
+	}
+	return nil
+}
+
+func formatCredentialRow(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		b.WriteString("  ")
+		b.WriteString(cell)
+		b.WriteString(strings.Repeat(" ", widths[i]-len(cell)))
+	}
+	b.WriteString("  ")
+	return b.String()
+}
+
+// CredentialDeleteOptions are the options for `porter credentials delete`.
+type CredentialDeleteOptions struct {
+	Name string
+}
+
+// DeleteCredential removes a credential set. Deleting a credential set
+// that doesn't exist isn't treated as an error: a message is printed and
+// nil is returned, so scripts calling delete don't need special-case
+// handling for "already gone".
+func (p *Porter) DeleteCredential(opts CredentialDeleteOptions) error {
+	if _, err := p.Credentials.Read(opts.Name); err != nil {
+		fmt.Fprintln(p.Out, "credential set does not exist")
+		return nil
+	}
+
+	return p.Credentials.Delete(opts.Name)
+}
+
+// GetCredentialSourceValueAndType returns the raw, unresolved value for a
+// credential source along with a short label for where it comes from, for
+// display in `porter credentials show`. Host-local sources
+// (env/path/command/value) are understood natively; anything else is
+// looked up in the pluggable external secrets registry so that sources
+// like `vault` or `aws-secretsmanager` are labeled correctly instead of
+// falling through as "unknown".
+func GetCredentialSourceValueAndType(source credentials.Source) (string, string) {
+	switch source.Key {
+	case host.SourceEnv:
+		return source.Value, "env"
+	case host.SourcePath:
+		return source.Value, "path"
+	case host.SourceCommand:
+		return source.Value, "command"
+	case host.SourceValue:
+		return source.Value, "value"
+	default:
+		if _, ok := secrets.Lookup(source.Key); ok {
+			return source.Value, secrets.Label(source.Key)
+		}
+		return source.Value, "unknown"
+	}
+}
+
+// ResolveCredentialSource returns the concrete secret value for source,
+// resolving it through the pluggable external secrets registry when it
+// isn't one of cnab-go's built-in host source types.
+func (p *Porter) ResolveCredentialSource(source credentials.Source) (string, error) {
+	switch source.Key {
+	case host.SourceEnv, host.SourcePath, host.SourceCommand, host.SourceValue:
+		return "", fmt.Errorf("host source %q should be resolved by cnab-go, not porter", source.Key)
+	default:
+		secretsCfg, err := p.GetSecretsConfig()
+		if err != nil {
+			return "", err
+		}
+		return secrets.Resolve(secretsCfg, source.Key, source.Value)
+	}
+}
+
+func validateFormat(f printer.Format) error {
+	switch f {
+	case printer.FormatJson, printer.FormatYaml, printer.FormatTable:
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", f)
+	}
+}
+
+type fmtWriter interface {
+	Write(p []byte) (int, error)
+}
+
+func printJson(w fmtWriter, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func printYaml(w fmtWriter, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
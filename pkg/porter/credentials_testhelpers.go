@@ -0,0 +1,66 @@
+package porter
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"get.porter.sh/porter/pkg/config"
+)
+
+// TestCredentialProvider wraps CredentialStorage with a helper for seeding
+// the test's temporary porter home from fixtures on disk.
+type TestCredentialProvider struct {
+	*CredentialStorage
+
+	testConfig *config.TestConfig
+}
+
+// NewTestCredentialProvider creates a TestCredentialProvider backed by
+// storage, writing fixtures through testConfig's filesystem.
+func NewTestCredentialProvider(storage *CredentialStorage, testConfig *config.TestConfig) *TestCredentialProvider {
+	return &TestCredentialProvider{
+		CredentialStorage: storage,
+		testConfig:        testConfig,
+	}
+}
+
+// AddTestCredentialsDirectory copies every credential set fixture in dir
+// (read from the real OS filesystem) into the test's temporary porter
+// home, so tests can seed pre-existing credential sets without hand
+// generating them.
+func (p *TestCredentialProvider) AddTestCredentialsDirectory(dir string) error {
+	home, err := p.testConfig.GetHomeDir()
+	if err != nil {
+		return err
+	}
+	credDir := filepath.Join(home, credentialsDirName)
+
+	if err := p.testConfig.FileSystem.MkdirAll(credDir, 0700); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		dest := filepath.Join(credDir, entry.Name())
+		if err := afero.WriteFile(p.testConfig.FileSystem, dest, data, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
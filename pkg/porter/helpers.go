@@ -0,0 +1,61 @@
+package porter
+
+import (
+	"testing"
+
+	"get.porter.sh/porter/pkg/config"
+)
+
+// TestPorter wraps Porter with test helpers: a throwaway porter home
+// directory, a throwaway Config, and a credential storage provider that's
+// easy to seed with fixtures.
+type TestPorter struct {
+	*Porter
+
+	TestConfig      *config.TestConfig
+	TestCredentials *TestCredentialProvider
+}
+
+// NewTestPorter creates a Porter for use in unit tests, backed by the real
+// filesystem under a temporary porter home, and no providers wired up. Tests set up whichever providers
+// (CNAB, etc) they exercise.
+func NewTestPorter(t *testing.T) *TestPorter {
+	tc := config.NewTestConfig(t)
+	credStorage := NewCredentialStorage(tc.Config)
+
+	return &TestPorter{
+		Porter: &Porter{
+			Config:      tc.Config,
+			Credentials: credStorage,
+		},
+		TestConfig:      tc,
+		TestCredentials: NewTestCredentialProvider(credStorage, tc),
+	}
+}
+
+// UseFilesystem returns the real, temporary porter home directory backing
+// this TestPorter. Delegates to the underlying TestConfig.
+func (p *TestPorter) UseFilesystem() string {
+	return p.TestConfig.UseFilesystem()
+}
+
+// TestCNABProvider is a no-op CNABProvider for tests, standing in for a
+// real bundle so that credential commands have something to default
+// names against.
+type TestCNABProvider struct {
+	// BundleCredentials, if set, is returned by GetBundleCredentials.
+	// Defaults to the credential names used by the testdata/test-creds
+	// fixtures.
+	BundleCredentials []string
+}
+
+func (p *TestCNABProvider) LoadBundle() (string, error) {
+	return "testbundle", nil
+}
+
+func (p *TestCNABProvider) GetBundleCredentials() ([]string, error) {
+	if p.BundleCredentials != nil {
+		return p.BundleCredentials, nil
+	}
+	return []string{"kool-config", "kool-envvar", "kool-cmd", "kool-val"}, nil
+}
@@ -0,0 +1,9 @@
+package porter
+
+import "get.porter.sh/porter/pkg/printer"
+
+// ListOptions are the options shared by every `porter <resource> list`
+// command.
+type ListOptions struct {
+	printer.PrintOptions
+}
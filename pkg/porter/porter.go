@@ -0,0 +1,41 @@
+package porter
+
+import (
+	"get.porter.sh/porter/pkg/config"
+)
+
+// CNABProvider is the subset of bundle tooling that the credential
+// commands need: enough to know which bundle is in scope so that
+// generated resources (like a new credential set) can default their name
+// to the bundle's.
+type CNABProvider interface {
+	// LoadBundle returns the name of the bundle in the current working
+	// context, used to default the name of generated credential sets.
+	LoadBundle() (string, error)
+
+	// GetBundleCredentials returns the names of the credentials declared
+	// by the bundle in the current working context, used to validate a
+	// credential set edited by hand against what the bundle actually
+	// expects.
+	GetBundleCredentials() ([]string, error)
+}
+
+// Porter is the logic behind the porter client. It ties together the
+// shared Config with the various resource providers (credentials,
+// bundles, etc).
+type Porter struct {
+	*config.Config
+
+	CNAB        CNABProvider
+	Credentials *CredentialStorage
+}
+
+// New creates a Porter instance wired up to the real OS filesystem and
+// providers.
+func New() *Porter {
+	cfg := config.New()
+	return &Porter{
+		Config:      cfg,
+		Credentials: NewCredentialStorage(cfg),
+	}
+}
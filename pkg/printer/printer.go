@@ -0,0 +1,31 @@
+package printer
+
+import "fmt"
+
+// Format is an output format supported by porter's list/show commands.
+type Format string
+
+const (
+	FormatJson  Format = "json"
+	FormatYaml  Format = "yaml"
+	FormatTable Format = "table"
+)
+
+// PrintOptions are the flags shared by commands that can render their
+// result in more than one format.
+type PrintOptions struct {
+	RawFormat string
+	Format    Format
+}
+
+// ParseFormat validates RawFormat against the set of formats a command
+// supports and, if valid, stores it in Format.
+func (o *PrintOptions) ParseFormat() error {
+	switch Format(o.RawFormat) {
+	case FormatJson, FormatYaml, FormatTable:
+		o.Format = Format(o.RawFormat)
+		return nil
+	default:
+		return fmt.Errorf("invalid format: %s", o.RawFormat)
+	}
+}
@@ -0,0 +1,52 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+func init() {
+	Register(SourceAWSSecretsManager, newAWSSecretsManagerProvider)
+}
+
+// awsSecretsManagerProvider resolves secrets from AWS Secrets Manager.
+// Source values are the secret name or ARN.
+type awsSecretsManagerProvider struct {
+	client *secretsmanager.Client
+}
+
+func newAWSSecretsManagerProvider(cfg BackendConfig) (Provider, error) {
+	opts := []func(*config.LoadOptions) error{}
+	if cfg.Address != "" {
+		// Address holds the region for this backend, e.g. "us-east-1".
+		opts = append(opts, config.WithRegion(cfg.Address))
+	}
+	if cfg.Role != "" {
+		opts = append(opts, config.WithSharedConfigProfile(cfg.Role))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading aws config: %w", err)
+	}
+
+	return &awsSecretsManagerProvider{client: secretsmanager.NewFromConfig(awsCfg)}, nil
+}
+
+func (p *awsSecretsManagerProvider) Resolve(sourceKey, sourceValue string) (string, error) {
+	out, err := p.client.GetSecretValue(context.Background(), &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(sourceValue),
+	})
+	if err != nil {
+		return "", fmt.Errorf("reading secretsmanager secret %q: %w", sourceValue, err)
+	}
+
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/keyvault/azsecrets"
+)
+
+func init() {
+	Register(SourceAzureKeyVault, newAzureKeyVaultProvider)
+}
+
+// azureKeyVaultProvider resolves secrets from Azure Key Vault. Source
+// values are the secret name, optionally suffixed with `/<version>`.
+type azureKeyVaultProvider struct {
+	client *azsecrets.Client
+}
+
+func newAzureKeyVaultProvider(cfg BackendConfig) (Provider, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("azure-keyvault backend requires address to be set to the vault URL")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure credential: %w", err)
+	}
+
+	client, err := azsecrets.NewClient(cfg.Address, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure keyvault client: %w", err)
+	}
+
+	return &azureKeyVaultProvider{client: client}, nil
+}
+
+func (p *azureKeyVaultProvider) Resolve(sourceKey, sourceValue string) (string, error) {
+	name, version := splitNameVersion(sourceValue)
+
+	resp, err := p.client.GetSecret(context.Background(), name, version, nil)
+	if err != nil {
+		return "", fmt.Errorf("reading keyvault secret %q: %w", sourceValue, err)
+	}
+	if resp.Value == nil {
+		return "", fmt.Errorf("keyvault secret %q has no value", sourceValue)
+	}
+	return *resp.Value, nil
+}
+
+// splitNameVersion splits a `<name>` or `<name>/<version>` source value
+// into its parts. version is "" when sourceValue names no particular
+// version, which azsecrets treats as "use the latest version".
+func splitNameVersion(sourceValue string) (name, version string) {
+	if i := strings.LastIndex(sourceValue, "/"); i != -1 {
+		return sourceValue[:i], sourceValue[i+1:]
+	}
+	return sourceValue, ""
+}
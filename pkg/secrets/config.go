@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// BackendConfig is the configuration for a single secret store backend, as
+// declared under a [[secrets.backend]] table in ~/.porter/config.toml:
+//
+//	[[secrets.backend]]
+//	source = "vault"
+//	address = "https://vault.example.com"
+//	role = "porter"
+type BackendConfig struct {
+	// Source is the credential source key this backend resolves, e.g.
+	// "vault" or "aws-secretsmanager".
+	Source  string            `toml:"source"`
+	Address string            `toml:"address"`
+	Role    string            `toml:"role"`
+	Extra   map[string]string `toml:"extra"`
+}
+
+// Config is the `[secrets]` section of porter's home configuration.
+type Config struct {
+	Backends []BackendConfig `toml:"backend"`
+}
+
+// Backend returns the configured backend for sourceKey, if any.
+func (c *Config) Backend(sourceKey string) (BackendConfig, bool) {
+	if c == nil {
+		return BackendConfig{}, false
+	}
+
+	for _, b := range c.Backends {
+		if b.Source == sourceKey {
+			return b, true
+		}
+	}
+	return BackendConfig{}, false
+}
+
+// ReadConfig parses the `[secrets]` section out of a porter config.toml.
+func ReadConfig(r io.Reader) (*Config, error) {
+	var doc struct {
+		Secrets Config `toml:"secrets"`
+	}
+
+	if _, err := toml.DecodeReader(r, &doc); err != nil {
+		return nil, fmt.Errorf("parsing secrets config: %w", err)
+	}
+
+	return &doc.Secrets, nil
+}
@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// dockerConfig mirrors the subset of ~/.docker/config.json that's
+// relevant to discovering which credential helper handles a registry.
+type dockerConfig struct {
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// DefaultDockerConfigPath returns the default location of the docker CLI
+// config file, honoring DOCKER_CONFIG like the docker CLI itself does.
+func DefaultDockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// DiscoverDockerCredentialHelper looks up which docker-credential-*
+// helper (without the "docker-credential-" prefix) is configured to
+// handle registry, reading configPath (normally
+// DefaultDockerConfigPath()). It falls back to the global credsStore
+// when there's no per-registry entry. ok is false when configPath
+// doesn't exist or names no helper for registry.
+func DiscoverDockerCredentialHelper(configPath, registry string) (helper string, ok bool, err error) {
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", false, err
+	}
+
+	if helper, ok := cfg.CredHelpers[registry]; ok && helper != "" {
+		return helper, true, nil
+	}
+	if cfg.CredsStore != "" {
+		return cfg.CredsStore, true, nil
+	}
+	return "", false, nil
+}
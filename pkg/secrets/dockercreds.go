@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// SourceDockerRegistry is the credential source key for resolving a value
+// via a `docker-credential-*` helper, e.g. `source: { docker-registry:
+// "ghcr.io" }`.
+const SourceDockerRegistry = "docker-registry"
+
+func init() {
+	Register(SourceDockerRegistry, newDockerCredentialHelperProvider)
+	displayLabels[SourceDockerRegistry] = "docker-registry"
+}
+
+// dockerCredentialHelperProvider resolves registry credentials by
+// shelling out to a `docker-credential-<helper>` binary already on PATH,
+// speaking its stdin/stdout "get" protocol. The sourceValue passed to
+// Resolve is the registry server URL (e.g. "ghcr.io").
+//
+// Extra["helper"] pins the helper binary to use, without the
+// "docker-credential-" prefix (e.g. "osxkeychain", "ecr-login"). When
+// it's unset, the helper is instead looked up per-registry from the
+// user's ~/.docker/config.json (credHelpers, falling back to
+// credsStore) at resolve time, the same way the docker CLI itself picks
+// a helper.
+type dockerCredentialHelperProvider struct {
+	helper string
+}
+
+func newDockerCredentialHelperProvider(cfg BackendConfig) (Provider, error) {
+	return &dockerCredentialHelperProvider{helper: cfg.Extra["helper"]}, nil
+}
+
+// dockerCredentialHelperOutput mirrors the JSON a docker-credential-*
+// helper writes to stdout in response to a "get" request.
+type dockerCredentialHelperOutput struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func (p *dockerCredentialHelperProvider) Resolve(sourceKey, sourceValue string) (string, error) {
+	helper := p.helper
+	if helper == "" {
+		discovered, ok, err := DiscoverDockerCredentialHelper(DefaultDockerConfigPath(), sourceValue)
+		if err != nil {
+			return "", fmt.Errorf("discovering docker credential helper for %q: %w", sourceValue, err)
+		}
+		if !ok {
+			return "", fmt.Errorf("no docker credential helper configured for registry %q and no extra.helper set", sourceValue)
+		}
+		helper = discovered
+	}
+
+	bin := "docker-credential-" + helper
+
+	cmd := exec.Command(bin, "get")
+	cmd.Stdin = bytes.NewBufferString(sourceValue)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s get %s: %w: %s", bin, sourceValue, err, stderr.String())
+	}
+
+	var out dockerCredentialHelperOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return "", fmt.Errorf("parsing %s output: %w", bin, err)
+	}
+
+	return out.Secret, nil
+}
@@ -0,0 +1,40 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+func init() {
+	Register(SourceGCPSecretManager, newGCPSecretManagerProvider)
+}
+
+// gcpSecretManagerProvider resolves secrets from Google Cloud Secret
+// Manager. Source values are the full resource name, e.g.
+// `projects/my-project/secrets/db-password/versions/latest`.
+type gcpSecretManagerProvider struct {
+	client *secretmanager.Client
+}
+
+func newGCPSecretManagerProvider(cfg BackendConfig) (Provider, error) {
+	client, err := secretmanager.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("creating gcp secretmanager client: %w", err)
+	}
+
+	return &gcpSecretManagerProvider{client: client}, nil
+}
+
+func (p *gcpSecretManagerProvider) Resolve(sourceKey, sourceValue string) (string, error) {
+	resp, err := p.client.AccessSecretVersion(context.Background(), &secretmanagerpb.AccessSecretVersionRequest{
+		Name: sourceValue,
+	})
+	if err != nil {
+		return "", fmt.Errorf("reading secretmanager secret %q: %w", sourceValue, err)
+	}
+
+	return string(resp.Payload.Data), nil
+}
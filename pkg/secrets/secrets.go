@@ -0,0 +1,84 @@
+// Package secrets provides a pluggable interface for resolving credential
+// values from external secret stores (Vault, cloud secret managers, etc),
+// as an alternative to the host-local source types (env/path/command/value)
+// that cnab-go understands natively.
+package secrets
+
+import "fmt"
+
+// Source keys understood by the built-in external secret store providers.
+// These are used as the key in a credential set's `source` map, e.g.
+// `source: { vault: "secret/data/db#password" }`.
+const (
+	SourceVault             = "vault"
+	SourceAWSSecretsManager = "aws-secretsmanager"
+	SourceAzureKeyVault     = "azure-keyvault"
+	SourceGCPSecretManager  = "gcp-secretmanager"
+)
+
+// displayLabels maps a source key to the short label porter prints in
+// credential list/show output.
+var displayLabels = map[string]string{
+	SourceVault:             "vault",
+	SourceAWSSecretsManager: "awssm",
+	SourceAzureKeyVault:     "azurekv",
+	SourceGCPSecretManager:  "gcpsm",
+}
+
+// Label returns the short display label porter uses for sourceKey in
+// credential list/show output, falling back to the key itself when it
+// isn't one of the built-in backends.
+func Label(sourceKey string) string {
+	if label, ok := displayLabels[sourceKey]; ok {
+		return label
+	}
+	return sourceKey
+}
+
+// Provider resolves a secret value from an external secret store, given
+// the sourceKey it was registered under and the sourceValue recorded in
+// a credential set (e.g. a Vault path, or an ARN). sourceKey is passed
+// through even though a Provider is already built for one specific key,
+// so a provider shared across related keys can tell them apart.
+type Provider interface {
+	Resolve(sourceKey, sourceValue string) (string, error)
+}
+
+// ProviderFactory builds a Provider from the backend configuration
+// declared for it in porter's config.toml.
+type ProviderFactory func(cfg BackendConfig) (Provider, error)
+
+var registry = map[string]ProviderFactory{}
+
+// Register adds a ProviderFactory for sourceKey to the registry. Built-in
+// providers call this from their own init().
+func Register(sourceKey string, factory ProviderFactory) {
+	registry[sourceKey] = factory
+}
+
+// Lookup reports whether a provider factory is registered for sourceKey.
+func Lookup(sourceKey string) (ProviderFactory, bool) {
+	factory, ok := registry[sourceKey]
+	return factory, ok
+}
+
+// Resolve resolves sourceValue using the provider registered for
+// sourceKey, built from the backend config declared for that key.
+func Resolve(cfg *Config, sourceKey, sourceValue string) (string, error) {
+	factory, ok := Lookup(sourceKey)
+	if !ok {
+		return "", fmt.Errorf("no secrets provider registered for source key %q", sourceKey)
+	}
+
+	backend, ok := cfg.Backend(sourceKey)
+	if !ok {
+		return "", fmt.Errorf("no [[secrets.backend]] configured for %q in ~/.porter/config.toml", sourceKey)
+	}
+
+	provider, err := factory(backend)
+	if err != nil {
+		return "", fmt.Errorf("initializing %s secrets provider: %w", sourceKey, err)
+	}
+
+	return provider.Resolve(sourceKey, sourceValue)
+}
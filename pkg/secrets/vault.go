@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"fmt"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+func init() {
+	Register(SourceVault, newVaultProvider)
+}
+
+// vaultProvider resolves secrets from a HashiCorp Vault KV store. Source
+// values are written as `<path>#<field>`, e.g. `secret/data/db#password`.
+type vaultProvider struct {
+	client *vaultapi.Client
+}
+
+func newVaultProvider(cfg BackendConfig) (Provider, error) {
+	vcfg := vaultapi.DefaultConfig()
+	if cfg.Address != "" {
+		vcfg.Address = cfg.Address
+	}
+
+	client, err := vaultapi.NewClient(vcfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating vault client: %w", err)
+	}
+
+	// Only a static token is supported: either extra.token in config.toml,
+	// or (if that's unset) whatever vaultapi.NewClient already picked up
+	// from VAULT_TOKEN. AppRole/Kubernetes login is not implemented, so
+	// cfg.Role is intentionally ignored here rather than clobbering a
+	// valid VAULT_TOKEN with an empty token.
+	if token := cfg.Extra["token"]; token != "" {
+		client.SetToken(token)
+	}
+
+	return &vaultProvider{client: client}, nil
+}
+
+func (p *vaultProvider) Resolve(sourceKey, sourceValue string) (string, error) {
+	path, field, err := splitPathField(sourceValue)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := p.client.Logical().Read(path)
+	if err != nil {
+		return "", fmt.Errorf("reading vault secret %q: %w", path, err)
+	}
+	if secret == nil {
+		return "", fmt.Errorf("no vault secret found at %q", path)
+	}
+
+	data := secret.Data
+	if nested, ok := secret.Data["data"].(map[string]interface{}); ok {
+		// KV v2 nests the actual secret under a "data" key.
+		data = nested
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %q has no field %q", path, field)
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %q field %q is not a string", path, field)
+	}
+
+	return s, nil
+}
+
+// splitPathField splits a `<path>#<field>` source value into its parts.
+func splitPathField(sourceValue string) (path string, field string, err error) {
+	parts := strings.SplitN(sourceValue, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid vault source value %q, expected format <path>#<field>", sourceValue)
+	}
+	return parts[0], parts[1], nil
+}